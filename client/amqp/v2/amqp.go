@@ -1,4 +1,12 @@
 // Package v2 provides a client with included tracing capabilities.
+//
+// BREAKING: this package now builds on github.com/rabbitmq/amqp091-go instead
+// of the archived github.com/streadway/amqp. Publisher confirms need
+// PublishWithDeferredConfirmWithContext, which streadway/amqp never gained.
+// amqp091-go keeps the same package name and a compatible API, but it is a
+// distinct Go module, so amqp.Publishing/amqp.Table values built against
+// streadway/amqp are not assignable to this package's Publish/PublishBatch
+// parameters; callers must switch their import too.
 package v2
 
 import (
@@ -6,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/beatlabs/patron/correlation"
@@ -15,11 +24,15 @@ import (
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/streadway/amqp"
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 const (
 	publisherComponent = "amqp-publisher"
+
+	defaultConfirmTimeout = 5 * time.Second
+	reconnectBaseDelay    = 500 * time.Millisecond
+	reconnectMaxDelay     = 30 * time.Second
 )
 
 var publishDurationMetrics *prometheus.HistogramVec
@@ -37,11 +50,42 @@ func init() {
 	prometheus.MustRegister(publishDurationMetrics)
 }
 
+// Message is a single message to publish as part of a PublishBatch call.
+type Message struct {
+	Exchange             string
+	Key                  string
+	Mandatory, Immediate bool
+	Publishing           amqp.Publishing
+}
+
+// amqpChannel is the subset of *amqp.Channel the publisher depends on, seamed out as an
+// interface (the same pattern client/sql/driver.go uses around driver.Conn) so tests can
+// exercise the reconnect/gating logic against a fake channel instead of a real broker.
+type amqpChannel interface {
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	PublishWithDeferredConfirmWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) (*amqp.DeferredConfirmation, error)
+	Confirm(noWait bool) error
+	NotifyClose(c chan *amqp.Error) chan *amqp.Error
+	Close() error
+}
+
 // Publisher defines a RabbitMQ publisher with tracing instrumentation.
 type Publisher struct {
-	cfg        *amqp.Config
+	url            string
+	cfg            *amqp.Config
+	confirms       bool
+	confirmTimeout time.Duration
+
+	mu         sync.RWMutex
 	connection *amqp.Connection
-	channel    *amqp.Channel
+	channel    amqpChannel
+	// ready is closed whenever connection/channel are usable. superviseConnection
+	// swaps in a fresh, open ready channel the instant a closure is detected, so
+	// that publishMessage can block on it until reconnect() installs a new channel
+	// and closes it again, instead of racing a stale, already-closed channel.
+	ready chan struct{}
+
+	closed chan struct{}
 }
 
 // New constructor.
@@ -50,35 +94,121 @@ func New(url string, oo ...OptionFunc) (*Publisher, error) {
 		return nil, errors.New("url is required")
 	}
 
-	var err error
-	pub := &Publisher{}
+	ready := make(chan struct{})
+	close(ready) // no reconnect is in flight yet
+
+	pub := &Publisher{
+		url:            url,
+		confirmTimeout: defaultConfirmTimeout,
+		ready:          ready,
+		closed:         make(chan struct{}),
+	}
 
 	for _, option := range oo {
-		err = option(pub)
-		if err != nil {
+		if err := option(pub); err != nil {
 			return nil, err
 		}
 	}
 
+	if err := pub.connect(); err != nil {
+		return nil, err
+	}
+
+	go pub.superviseConnection()
+
+	return pub, nil
+}
+
+// connect dials the broker, opens a channel and, if enabled, puts it into confirm mode.
+func (tc *Publisher) connect() error {
 	var conn *amqp.Connection
+	var err error
 
-	if pub.cfg == nil {
-		conn, err = amqp.Dial(url)
+	if tc.cfg == nil {
+		conn, err = amqp.Dial(tc.url)
 	} else {
-		conn, err = amqp.DialConfig(url, *pub.cfg)
+		conn, err = amqp.DialConfig(tc.url, *tc.cfg)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to open connection: %w", err)
+		return fmt.Errorf("failed to open connection: %w", err)
 	}
 
 	ch, err := conn.Channel()
 	if err != nil {
-		return nil, patronerrors.Aggregate(fmt.Errorf("failed to open channel: %w", err), conn.Close())
+		return patronerrors.Aggregate(fmt.Errorf("failed to open channel: %w", err), conn.Close())
 	}
 
-	pub.connection = conn
-	pub.channel = ch
-	return pub, nil
+	if tc.confirms {
+		if err := ch.Confirm(false); err != nil {
+			return patronerrors.Aggregate(fmt.Errorf("failed to enable publisher confirms: %w", err), ch.Close(), conn.Close())
+		}
+	}
+
+	tc.mu.Lock()
+	tc.connection = conn
+	tc.channel = ch
+	tc.mu.Unlock()
+
+	return nil
+}
+
+// superviseConnection watches the current connection and channel for closure and transparently
+// redials and re-opens a confirmed channel with exponential backoff, so that concurrent callers
+// of Publish/PublishBatch see a transparent reconnect rather than a permanent error.
+func (tc *Publisher) superviseConnection() {
+	for {
+		tc.mu.RLock()
+		conn := tc.connection
+		ch := tc.channel
+		tc.mu.RUnlock()
+
+		connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+		chClosed := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+		select {
+		case <-tc.closed:
+			return
+		case <-connClosed:
+		case <-chClosed:
+		}
+
+		select {
+		case <-tc.closed:
+			return
+		default:
+		}
+
+		tc.mu.Lock()
+		notReady := make(chan struct{})
+		tc.ready = notReady
+		tc.mu.Unlock()
+
+		tc.reconnect(notReady)
+	}
+}
+
+// reconnect redials with exponential backoff until connect succeeds or the publisher is closed,
+// then closes notReady so that publishers blocked in awaitChannel resume against the new channel.
+func (tc *Publisher) reconnect(notReady chan struct{}) {
+	delay := reconnectBaseDelay
+	for {
+		select {
+		case <-tc.closed:
+			return
+		default:
+		}
+
+		if err := tc.connect(); err == nil {
+			close(notReady)
+			return
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+		if delay > reconnectMaxDelay {
+			delay = reconnectMaxDelay
+		}
+	}
 }
 
 // Publish a message to a exchange.
@@ -98,7 +228,7 @@ func (tc *Publisher) Publish(ctx context.Context, exchange, key string, mandator
 	msg.Headers[correlation.HeaderID] = correlation.IDFromContext(ctx)
 
 	start := time.Now()
-	err := tc.channel.Publish(exchange, key, mandatory, immediate, msg)
+	err := tc.publishMessage(ctx, exchange, key, mandatory, immediate, msg)
 
 	observePublish(sp, start, exchange, err)
 	if err != nil {
@@ -108,9 +238,120 @@ func (tc *Publisher) Publish(ctx context.Context, exchange, key string, mandator
 	return nil
 }
 
+// PublishBatch publishes a batch of messages under a single span tagged with the batch size,
+// observing one publishDurationMetrics sample per message. The returned slice has the same
+// length and order as msgs, with a nil entry for every message that was published successfully.
+func (tc *Publisher) PublishBatch(ctx context.Context, msgs []Message) []error {
+	sp, _ := trace.ChildSpan(ctx, trace.ComponentOpName(publisherComponent, "batch"),
+		publisherComponent, ext.SpanKindProducer, opentracing.Tag{Key: "batch.size", Value: len(msgs)})
+
+	errs := make([]error, len(msgs))
+	var batchErr error
+
+	for i, m := range msgs {
+		msg := m.Publishing
+		if msg.Headers == nil {
+			msg.Headers = amqp.Table{}
+		}
+
+		c := amqpHeadersCarrier(msg.Headers)
+		if err := sp.Tracer().Inject(sp.Context(), opentracing.TextMap, c); err != nil {
+			log.FromContext(ctx).Errorf("failed to inject tracing headers: %v", err)
+		}
+		msg.Headers[correlation.HeaderID] = correlation.IDFromContext(ctx)
+
+		start := time.Now()
+		err := tc.publishMessage(ctx, m.Exchange, m.Key, m.Mandatory, m.Immediate, msg)
+		publishDurationMetrics.WithLabelValues(m.Exchange, strconv.FormatBool(err != nil)).Observe(time.Since(start).Seconds())
+
+		if err != nil {
+			err = fmt.Errorf("failed to publish message %d: %w", i, err)
+			batchErr = err
+		}
+		errs[i] = err
+	}
+
+	trace.SpanComplete(sp, batchErr)
+	return errs
+}
+
+// publishMessage publishes msg on the current channel, blocking on the broker's confirm when
+// publisher confirms are enabled. If the channel dies between awaitChannel returning it and the
+// publish call landing, it waits for the channel superviseConnection installs next and retries,
+// so that callers see a transparent reconnect rather than a spurious channel-closed error.
+func (tc *Publisher) publishMessage(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	for {
+		ch, confirms, timeout, err := tc.awaitChannel(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = tc.doPublish(ctx, ch, exchange, key, mandatory, immediate, msg, confirms, timeout)
+		if errors.Is(err, amqp.ErrClosed) {
+			continue
+		}
+
+		return err
+	}
+}
+
+// awaitChannel blocks until connection/channel are usable, then returns a consistent snapshot of
+// them along with the confirms configuration. The channel is read only after ready has fired, not
+// before: reconnect() closes ready only after connect() has installed the new tc.channel, but a
+// caller can be parked in the select below while that swap happens, so a pre-wait snapshot could
+// still be the dead channel being replaced.
+func (tc *Publisher) awaitChannel(ctx context.Context) (ch amqpChannel, confirms bool, timeout time.Duration, err error) {
+	tc.mu.RLock()
+	ready := tc.ready
+	tc.mu.RUnlock()
+
+	select {
+	case <-ready:
+	case <-tc.closed:
+		return nil, false, 0, errors.New("publisher is closed")
+	case <-ctx.Done():
+		return nil, false, 0, ctx.Err()
+	}
+
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return tc.channel, tc.confirms, tc.confirmTimeout, nil
+}
+
+func (tc *Publisher) doPublish(ctx context.Context, ch amqpChannel, exchange, key string, mandatory, immediate bool, msg amqp.Publishing, confirms bool, timeout time.Duration) error {
+	if !confirms {
+		return ch.PublishWithContext(ctx, exchange, key, mandatory, immediate, msg)
+	}
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, mandatory, immediate, msg)
+	if err != nil {
+		return err
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ok, err := confirmation.WaitContext(cctx)
+	if err != nil {
+		return fmt.Errorf("timed out waiting for publisher confirm: %w", err)
+	}
+	if !ok {
+		return errors.New("broker nacked the message")
+	}
+
+	return nil
+}
+
 // Close the channel and connection.
 func (tc *Publisher) Close() error {
-	return patronerrors.Aggregate(tc.channel.Close(), tc.connection.Close())
+	close(tc.closed)
+
+	tc.mu.RLock()
+	ch := tc.channel
+	conn := tc.connection
+	tc.mu.RUnlock()
+
+	return patronerrors.Aggregate(ch.Close(), conn.Close())
 }
 
 type amqpHeadersCarrier map[string]interface{}
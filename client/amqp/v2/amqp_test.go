@@ -0,0 +1,143 @@
+package v2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// fakeChannel is a minimal amqpChannel double that records the publishes it receives.
+type fakeChannel struct {
+	published []string
+}
+
+func (f *fakeChannel) PublishWithContext(_ context.Context, exchange, key string, _, _ bool, _ amqp.Publishing) error {
+	f.published = append(f.published, exchange+"/"+key)
+	return nil
+}
+
+func (f *fakeChannel) PublishWithDeferredConfirmWithContext(context.Context, string, string, bool, bool, amqp.Publishing) (*amqp.DeferredConfirmation, error) {
+	return nil, nil
+}
+
+func (f *fakeChannel) Confirm(bool) error {
+	return nil
+}
+
+func (f *fakeChannel) NotifyClose(c chan *amqp.Error) chan *amqp.Error {
+	return c
+}
+
+func (f *fakeChannel) Close() error {
+	return nil
+}
+
+// TestPublisher_awaitChannel_ReconnectSwapsChannel exercises the race review round 2 flagged:
+// a caller parked in awaitChannel's select while reconnect() swaps tc.channel must observe the
+// new channel once ready fires, never the stale one it was blocking on.
+func TestPublisher_awaitChannel_ReconnectSwapsChannel(t *testing.T) {
+	oldCh := &fakeChannel{}
+	newCh := &fakeChannel{}
+
+	notReady := make(chan struct{})
+	tc := &Publisher{
+		channel: oldCh,
+		ready:   notReady,
+		closed:  make(chan struct{}),
+	}
+
+	type result struct {
+		ch  amqpChannel
+		err error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		ch, _, _, err := tc.awaitChannel(context.Background())
+		resCh <- result{ch: ch, err: err}
+	}()
+
+	// Give awaitChannel a chance to park on <-ready before the swap happens.
+	time.Sleep(10 * time.Millisecond)
+
+	// Mimic reconnect(): install the new channel under the lock, then close notReady.
+	tc.mu.Lock()
+	tc.channel = newCh
+	tc.mu.Unlock()
+	close(notReady)
+
+	select {
+	case res := <-resCh:
+		if res.err != nil {
+			t.Fatalf("awaitChannel returned unexpected error: %v", res.err)
+		}
+		if res.ch != amqpChannel(newCh) {
+			t.Fatal("awaitChannel returned the stale channel instead of the one reconnect() installed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("awaitChannel did not return after ready fired")
+	}
+}
+
+// TestPublisher_awaitChannel_ClosedPublisher ensures a closed publisher unblocks waiters
+// instead of leaving them parked forever.
+func TestPublisher_awaitChannel_ClosedPublisher(t *testing.T) {
+	tc := &Publisher{
+		channel: &fakeChannel{},
+		ready:   make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+	close(tc.closed)
+
+	_, _, _, err := tc.awaitChannel(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for a closed publisher, got nil")
+	}
+}
+
+// TestPublisher_publishMessage_RetriesOnClosedChannel covers publishMessage's retry loop: a
+// first attempt against a channel that reports ErrClosed must wait for the next ready gate and
+// retry against the channel that replaces it, rather than surfacing the stale error.
+func TestPublisher_publishMessage_RetriesOnClosedChannel(t *testing.T) {
+	closingCh := &closedOnceChannel{}
+	newCh := &fakeChannel{}
+
+	ready := make(chan struct{})
+	close(ready)
+	tc := &Publisher{
+		channel: closingCh,
+		ready:   ready,
+		closed:  make(chan struct{}),
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		notReady := make(chan struct{})
+		tc.mu.Lock()
+		tc.ready = notReady
+		tc.mu.Unlock()
+
+		tc.mu.Lock()
+		tc.channel = newCh
+		tc.mu.Unlock()
+		close(notReady)
+	}()
+
+	err := tc.publishMessage(context.Background(), "exchange", "key", false, false, amqp.Publishing{})
+	if err != nil {
+		t.Fatalf("publishMessage returned unexpected error: %v", err)
+	}
+	if len(newCh.published) != 1 {
+		t.Fatalf("expected the retry to land on the new channel, got %d publishes on it", len(newCh.published))
+	}
+}
+
+// closedOnceChannel fails its first publish with amqp.ErrClosed, as a dead channel would.
+type closedOnceChannel struct {
+	fakeChannel
+}
+
+func (f *closedOnceChannel) PublishWithContext(context.Context, string, string, bool, bool, amqp.Publishing) error {
+	return amqp.ErrClosed
+}
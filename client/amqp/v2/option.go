@@ -0,0 +1,33 @@
+package v2
+
+import (
+	"errors"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// OptionFunc defines a function for configuring the publisher.
+type OptionFunc func(*Publisher) error
+
+// WithConfig sets the amqp.Config used to dial the broker.
+func WithConfig(cfg amqp.Config) OptionFunc {
+	return func(p *Publisher) error {
+		p.cfg = &cfg
+		return nil
+	}
+}
+
+// WithPublisherConfirms puts the channel into confirm mode. Publish and PublishBatch then
+// block on the broker's ack/nack for up to timeout, returning an error (observed as
+// success="false") when the broker nacks the message or the deadline fires.
+func WithPublisherConfirms(timeout time.Duration) OptionFunc {
+	return func(p *Publisher) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+		p.confirms = true
+		p.confirmTimeout = timeout
+		return nil
+	}
+}
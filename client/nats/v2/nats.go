@@ -0,0 +1,149 @@
+// Package v2 provides a client with included tracing capabilities.
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/log"
+	"github.com/beatlabs/patron/trace"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	publisherComponent = "nats-publisher"
+)
+
+var publishDurationMetrics *prometheus.HistogramVec
+
+func init() {
+	publishDurationMetrics = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "client",
+			Subsystem: "nats",
+			Name:      "publish_duration_seconds",
+			Help:      "NATS publish completed by the client.",
+		},
+		[]string{"subject", "stream", "success"},
+	)
+	prometheus.MustRegister(publishDurationMetrics)
+}
+
+// Publisher defines a NATS publisher with tracing instrumentation.
+type Publisher struct {
+	opts []nats.Option
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+// New constructor.
+func New(url string, oo ...OptionFunc) (*Publisher, error) {
+	if url == "" {
+		return nil, errors.New("url is required")
+	}
+
+	pub := &Publisher{}
+
+	for _, option := range oo {
+		if err := option(pub); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := nats.Connect(url, pub.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create jetstream context: %w", err)
+	}
+
+	pub.conn = conn
+	pub.js = js
+	return pub, nil
+}
+
+// Publish a message to a subject over core NATS.
+func (p *Publisher) Publish(ctx context.Context, subject string, data []byte, hdr nats.Header) error {
+	sp, _ := trace.ChildSpan(ctx, trace.ComponentOpName(publisherComponent, subject),
+		publisherComponent, ext.SpanKindProducer, opentracing.Tag{Key: "subject", Value: subject})
+
+	if hdr == nil {
+		hdr = nats.Header{}
+	}
+
+	c := natsHeadersCarrier(hdr)
+
+	if err := sp.Tracer().Inject(sp.Context(), opentracing.TextMap, c); err != nil {
+		log.FromContext(ctx).Errorf("failed to inject tracing headers: %v", err)
+	}
+	hdr.Set(correlation.HeaderID, correlation.IDFromContext(ctx))
+
+	start := time.Now()
+	err := p.conn.PublishMsg(&nats.Msg{Subject: subject, Data: data, Header: hdr})
+
+	observePublish(sp, start, subject, "", err)
+	if err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	return nil
+}
+
+// PublishJetStream publishes a message to a subject through JetStream, waiting for the broker's ack.
+func (p *Publisher) PublishJetStream(ctx context.Context, subject string, data []byte, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+	sp, _ := trace.ChildSpan(ctx, trace.ComponentOpName(publisherComponent, subject),
+		publisherComponent, ext.SpanKindProducer, opentracing.Tag{Key: "subject", Value: subject})
+
+	hdr := nats.Header{}
+	c := natsHeadersCarrier(hdr)
+
+	if err := sp.Tracer().Inject(sp.Context(), opentracing.TextMap, c); err != nil {
+		log.FromContext(ctx).Errorf("failed to inject tracing headers: %v", err)
+	}
+	hdr.Set(correlation.HeaderID, correlation.IDFromContext(ctx))
+
+	start := time.Now()
+	ack, err := p.js.PublishMsg(ctx, &nats.Msg{Subject: subject, Data: data, Header: hdr}, opts...)
+
+	stream := ""
+	if ack != nil {
+		stream = ack.Stream
+	}
+	sp.SetTag("stream", stream)
+
+	observePublish(sp, start, subject, stream, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	return ack, nil
+}
+
+// Close drains and closes the underlying NATS connection.
+func (p *Publisher) Close() error {
+	return p.conn.Drain()
+}
+
+type natsHeadersCarrier nats.Header
+
+// Set implements Set() of opentracing.TextMapWriter.
+func (c natsHeadersCarrier) Set(key, val string) {
+	c[key] = []string{val}
+}
+
+func observePublish(span opentracing.Span, start time.Time, subject, stream string, err error) {
+	trace.SpanComplete(span, err)
+	publishDurationMetrics.WithLabelValues(subject, stream, strconv.FormatBool(err != nil)).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,41 @@
+package v2
+
+import (
+	"crypto/tls"
+	"errors"
+
+	"github.com/nats-io/nats.go"
+)
+
+// OptionFunc defines a function for configuring the publisher.
+type OptionFunc func(*Publisher) error
+
+// WithTLSConfig sets a custom tls.Config, enabling a secure connection to the NATS server.
+func WithTLSConfig(cfg *tls.Config) OptionFunc {
+	return func(p *Publisher) error {
+		if cfg == nil {
+			return errors.New("tls config is required")
+		}
+		p.opts = append(p.opts, nats.Secure(cfg))
+		return nil
+	}
+}
+
+// WithCredentialsFile configures the publisher to authenticate using a NATS credentials file.
+func WithCredentialsFile(file string) OptionFunc {
+	return func(p *Publisher) error {
+		if file == "" {
+			return errors.New("credentials file is required")
+		}
+		p.opts = append(p.opts, nats.UserCredentials(file))
+		return nil
+	}
+}
+
+// WithOptions appends raw nats.Option values, for configuration not covered by a dedicated option.
+func WithOptions(oo ...nats.Option) OptionFunc {
+	return func(p *Publisher) error {
+		p.opts = append(p.opts, oo...)
+		return nil
+	}
+}
@@ -0,0 +1,352 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/beatlabs/patron/trace"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// WrapDriver registers a traced shim around drv under a generated name and
+// returns that name, so that sql.Open(sql.WrapDriver("mysql", mysqlDriver), dsn)
+// returns a stock *sql.DB that is fully instrumented, including rows iteration,
+// without requiring callers to go through the DB/Conn/Tx/Stmt facade in this
+// package. This makes the resulting *sql.DB a drop-in for libraries such as
+// sqlx, GORM or goose that expect a plain *sql.DB.
+//
+// Calling WrapDriver again for the same name is a no-op: sql.Register panics
+// on a duplicate name, which would otherwise take down any process that opens
+// more than one pool, or re-runs test setup, against the same driver.
+func WrapDriver(name string, drv driver.Driver) string {
+	wrapped := name + "-patron"
+
+	registeredDriversMu.Lock()
+	defer registeredDriversMu.Unlock()
+
+	if !registeredDrivers[wrapped] {
+		sql.Register(wrapped, &tracedDriver{drv: drv})
+		registeredDrivers[wrapped] = true
+	}
+
+	return wrapped
+}
+
+var (
+	registeredDriversMu sync.Mutex
+	registeredDrivers   = make(map[string]bool)
+)
+
+// WrapConnector wraps c so that every connection it opens is instrumented the
+// same way as a driver registered through WrapDriver. Use it together with
+// sql.OpenDB when a driver.Connector is already available, e.g.
+// sql.OpenDB(sql.WrapConnector(c)).
+func WrapConnector(c driver.Connector) driver.Connector {
+	return &tracedConnector{connector: c, driver: &tracedDriver{drv: c.Driver()}}
+}
+
+// startDriverSpan starts a span for a traced driver operation. Unlike
+// connInfo.startSpan, the driver shim has no DSNInfo to tag the span with,
+// since it sits below the DB/Conn/Tx/Stmt facade and is reached directly by
+// libraries that only know about database/sql.
+func startDriverSpan(ctx context.Context, op string) (opentracing.Span, context.Context) {
+	sp, ctx := opentracing.StartSpanFromContext(ctx, op)
+	ext.Component.Set(sp, component)
+	ext.DBType.Set(sp, dbtype)
+	sp.SetTag(trace.VersionTag, trace.Version)
+	return sp, ctx
+}
+
+// observeOpDuration records op duration for driver callbacks that receive no
+// context (e.g. driver.Conn.Prepare, driver.Tx.Commit), so no span can be
+// started for them.
+func observeOpDuration(start time.Time, op string, err error) {
+	opDurationMetrics.WithLabelValues(op, strconv.FormatBool(err != nil)).Observe(time.Since(start).Seconds())
+}
+
+type tracedDriver struct {
+	drv driver.Driver
+}
+
+// Open implements driver.Driver.
+func (d *tracedDriver) Open(dsn string) (driver.Conn, error) {
+	op := "driver.Open"
+	start := time.Now()
+	conn, err := d.drv.Open(dsn)
+	observeOpDuration(start, op, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{conn: conn}, nil
+}
+
+// OpenConnector implements driver.DriverContext, if the wrapped driver supports it.
+func (d *tracedDriver) OpenConnector(dsn string) (driver.Connector, error) {
+	dc, ok := d.drv.(driver.DriverContext)
+	if !ok {
+		return dsnConnector{dsn: dsn, driver: d}, nil
+	}
+	c, err := dc.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConnector{connector: c, driver: d}, nil
+}
+
+// dsnConnector mirrors the unexported helper database/sql uses internally to
+// turn a driver.Driver without OpenConnector support into a driver.Connector.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (t dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return t.driver.Open(t.dsn)
+}
+
+func (t dsnConnector) Driver() driver.Driver {
+	return t.driver
+}
+
+type tracedConnector struct {
+	connector driver.Connector
+	driver    driver.Driver
+}
+
+// Connect implements driver.Connector.
+func (c *tracedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	op := "driver.Connect"
+	sp, ctx := startDriverSpan(ctx, op)
+	start := time.Now()
+	conn, err := c.connector.Connect(ctx)
+	observeDuration(sp, start, op, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{conn: conn}, nil
+}
+
+// Driver implements driver.Connector.
+func (c *tracedConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// tracedConn wraps a driver.Conn, tracing every operation that the sql
+// package may invoke on it. It always implements the context-aware optional
+// interfaces. When the wrapped connection doesn't support one, ExecContext and
+// QueryContext return driver.ErrSkip so that database/sql falls back to
+// preparing a statement, while PrepareContext and ConnBeginTx call the wrapped
+// connection's Prepare and Begin instead.
+type tracedConn struct {
+	conn driver.Conn
+}
+
+// Prepare implements driver.Conn.
+func (c *tracedConn) Prepare(query string) (driver.Stmt, error) {
+	op := "conn.Prepare"
+	start := time.Now()
+	stmt, err := c.conn.Prepare(query)
+	observeOpDuration(start, op, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedStmt{stmt: stmt, query: query}, nil
+}
+
+// Close implements driver.Conn.
+func (c *tracedConn) Close() error {
+	return c.conn.Close()
+}
+
+// Begin implements driver.Conn.
+func (c *tracedConn) Begin() (driver.Tx, error) { //nolint:staticcheck
+	tx, err := c.conn.Begin() //nolint:staticcheck
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{tx: tx}, nil
+}
+
+// PrepareContext implements driver.ConnPrepareContext.
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	op := "conn.PrepareContext"
+	cpc, ok := c.conn.(driver.ConnPrepareContext)
+	if !ok {
+		return c.Prepare(query)
+	}
+	sp, ctx := startDriverSpan(ctx, op)
+	start := time.Now()
+	stmt, err := cpc.PrepareContext(ctx, query)
+	observeDuration(sp, start, op, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedStmt{stmt: stmt, query: query}, nil
+}
+
+// ConnBeginTx implements driver.ConnBeginTx.
+func (c *tracedConn) ConnBeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	op := "conn.BeginTx"
+	cbt, ok := c.conn.(driver.ConnBeginTx)
+	if !ok {
+		return c.Begin()
+	}
+	sp, ctx := startDriverSpan(ctx, op)
+	start := time.Now()
+	tx, err := cbt.ConnBeginTx(ctx, opts)
+	observeDuration(sp, start, op, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{tx: tx}, nil
+}
+
+// ExecContext implements driver.ExecerContext.
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	op := "conn.ExecContext"
+	execer, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	sp, ctx := startDriverSpan(ctx, op)
+	start := time.Now()
+	res, err := execer.ExecContext(ctx, query, args)
+	observeDuration(sp, start, op, err)
+	return res, err
+}
+
+// QueryContext implements driver.QueryerContext.
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	op := "conn.QueryContext"
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	sp, ctx := startDriverSpan(ctx, op)
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	observeDuration(sp, start, op, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedRows{rows: rows}, nil
+}
+
+type tracedStmt struct {
+	stmt  driver.Stmt
+	query string
+}
+
+func (s *tracedStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *tracedStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *tracedStmt) Exec(args []driver.Value) (driver.Result, error) { //nolint:staticcheck
+	return s.stmt.Exec(args) //nolint:staticcheck
+}
+
+func (s *tracedStmt) Query(args []driver.Value) (driver.Rows, error) { //nolint:staticcheck
+	rows, err := s.stmt.Query(args) //nolint:staticcheck
+	if err != nil {
+		return nil, err
+	}
+	return &tracedRows{rows: rows}, nil
+}
+
+// ExecContext implements driver.StmtExecContext.
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	op := "stmt.ExecContext"
+	execer, ok := s.stmt.(driver.StmtExecContext)
+	if !ok {
+		// database/sql does not treat driver.ErrSkip from a statement as a
+		// fallback, so the non-context Exec is called here instead.
+		vals := make([]driver.Value, len(args))
+		for i, a := range args {
+			vals[i] = a.Value
+		}
+		return s.Exec(vals) //nolint:staticcheck
+	}
+	sp, ctx := startDriverSpan(ctx, op)
+	ext.DBStatement.Set(sp, s.query)
+	start := time.Now()
+	res, err := execer.ExecContext(ctx, args)
+	observeDuration(sp, start, op, err)
+	return res, err
+}
+
+// QueryContext implements driver.StmtQueryContext.
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	op := "stmt.QueryContext"
+	queryer, ok := s.stmt.(driver.StmtQueryContext)
+	if !ok {
+		// See ExecContext: fall back to the non-context Query.
+		vals := make([]driver.Value, len(args))
+		for i, a := range args {
+			vals[i] = a.Value
+		}
+		return s.Query(vals) //nolint:staticcheck
+	}
+	sp, ctx := startDriverSpan(ctx, op)
+	ext.DBStatement.Set(sp, s.query)
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, args)
+	observeDuration(sp, start, op, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedRows{rows: rows}, nil
+}
+
+type tracedTx struct {
+	tx driver.Tx
+}
+
+// Commit implements driver.Tx.
+func (t *tracedTx) Commit() error {
+	op := "tx.Commit"
+	start := time.Now()
+	err := t.tx.Commit()
+	observeOpDuration(start, op, err)
+	return err
+}
+
+// Rollback implements driver.Tx.
+func (t *tracedTx) Rollback() error {
+	op := "tx.Rollback"
+	start := time.Now()
+	err := t.tx.Rollback()
+	observeOpDuration(start, op, err)
+	return err
+}
+
+// tracedRows wraps driver.Rows so that row iteration is observed under the
+// same op label the rest of this package uses.
+type tracedRows struct {
+	rows driver.Rows
+}
+
+func (r *tracedRows) Columns() []string {
+	return r.rows.Columns()
+}
+
+func (r *tracedRows) Close() error {
+	return r.rows.Close()
+}
+
+// Next implements driver.Rows.
+func (r *tracedRows) Next(dest []driver.Value) error {
+	op := "rows.Next"
+	start := time.Now()
+	err := r.rows.Next(dest)
+	observeOpDuration(start, op, err)
+	return err
+}
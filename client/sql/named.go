@@ -0,0 +1,350 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// execQueryer is satisfied by *sql.DB, *sql.Conn and *sql.Tx alike, letting
+// NamedExec, NamedQuery, Get and Select share one implementation across the
+// DB/Conn/Tx facade.
+type execQueryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// NamedExec executes a query with `:name` placeholders bound from the exported
+// fields of arg (or the `db` tag, if present), rewriting the placeholders into
+// positional ones for the driver name the connection was opened with. A DB
+// created through OpenDB or FromDB carries no driver name and gets ? placeholders.
+func (db *DB) NamedExec(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return namedExec(ctx, db.db, &db.connInfo, "db", query, arg)
+}
+
+// NamedQuery is like NamedExec but for queries that return rows.
+func (db *DB) NamedQuery(ctx context.Context, query string, arg interface{}) (*sql.Rows, error) {
+	return namedQuery(ctx, db.db, &db.connInfo, "db", query, arg)
+}
+
+// Get executes query and scans the first returned row into dest, a pointer to
+// a struct. Columns are matched against the struct's `db` tags, falling back
+// to the lower-cased field name. It returns sql.ErrNoRows if no row matched.
+func (db *DB) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return get(ctx, db.db, &db.connInfo, "db", dest, query, args...)
+}
+
+// Select executes query and scans every returned row into dest, a pointer to
+// a slice of structs, using the same column matching rules as Get.
+func (db *DB) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return selectRows(ctx, db.db, &db.connInfo, "db", dest, query, args...)
+}
+
+// NamedExec executes a query with `:name` placeholders bound from arg. See DB.NamedExec.
+func (c *Conn) NamedExec(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return namedExec(ctx, c.conn, &c.connInfo, "conn", query, arg)
+}
+
+// NamedQuery is like NamedExec but for queries that return rows.
+func (c *Conn) NamedQuery(ctx context.Context, query string, arg interface{}) (*sql.Rows, error) {
+	return namedQuery(ctx, c.conn, &c.connInfo, "conn", query, arg)
+}
+
+// Get executes query and scans the first returned row into dest. See DB.Get.
+func (c *Conn) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return get(ctx, c.conn, &c.connInfo, "conn", dest, query, args...)
+}
+
+// Select executes query and scans every returned row into dest. See DB.Select.
+func (c *Conn) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return selectRows(ctx, c.conn, &c.connInfo, "conn", dest, query, args...)
+}
+
+// NamedExec executes a query with `:name` placeholders bound from arg. See DB.NamedExec.
+func (tx *Tx) NamedExec(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return namedExec(ctx, tx.tx, &tx.connInfo, "tx", query, arg)
+}
+
+// NamedQuery is like NamedExec but for queries that return rows.
+func (tx *Tx) NamedQuery(ctx context.Context, query string, arg interface{}) (*sql.Rows, error) {
+	return namedQuery(ctx, tx.tx, &tx.connInfo, "tx", query, arg)
+}
+
+// Get executes query and scans the first returned row into dest. See DB.Get.
+func (tx *Tx) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return get(ctx, tx.tx, &tx.connInfo, "tx", dest, query, args...)
+}
+
+// Select executes query and scans every returned row into dest. See DB.Select.
+func (tx *Tx) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return selectRows(ctx, tx.tx, &tx.connInfo, "tx", dest, query, args...)
+}
+
+func namedExec(ctx context.Context, eq execQueryer, ci *connInfo, opPrefix, query string, arg interface{}) (sql.Result, error) {
+	op := opPrefix + ".NamedExec"
+	rewritten, args, err := bindNamed(ci.driver, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	sp, ctx := ci.startSpan(ctx, op, rewritten)
+	start := time.Now()
+	res, err := eq.ExecContext(ctx, rewritten, args...)
+	observeDuration(sp, start, op, err)
+	return res, err
+}
+
+func namedQuery(ctx context.Context, eq execQueryer, ci *connInfo, opPrefix, query string, arg interface{}) (*sql.Rows, error) {
+	op := opPrefix + ".NamedQuery"
+	rewritten, args, err := bindNamed(ci.driver, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	sp, ctx := ci.startSpan(ctx, op, rewritten)
+	start := time.Now()
+	rows, err := eq.QueryContext(ctx, rewritten, args...)
+	observeDuration(sp, start, op, err)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func get(ctx context.Context, eq execQueryer, ci *connInfo, opPrefix string, dest interface{}, query string, args ...interface{}) error {
+	op := opPrefix + ".Get"
+	sp, ctx := ci.startSpan(ctx, op, query)
+	start := time.Now()
+
+	rows, err := eq.QueryContext(ctx, query, args...)
+	if err != nil {
+		observeDuration(sp, start, op, err)
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err = rows.Err(); err == nil {
+			err = sql.ErrNoRows
+		}
+		observeDuration(sp, start, op, err)
+		return err
+	}
+
+	err = scanRow(rows, dest)
+	observeDuration(sp, start, op, err)
+	return err
+}
+
+func selectRows(ctx context.Context, eq execQueryer, ci *connInfo, opPrefix string, dest interface{}, query string, args ...interface{}) error {
+	op := opPrefix + ".Select"
+	sp, ctx := ci.startSpan(ctx, op, query)
+	start := time.Now()
+
+	rows, err := eq.QueryContext(ctx, query, args...)
+	if err != nil {
+		observeDuration(sp, start, op, err)
+		return err
+	}
+	defer rows.Close()
+
+	err = scanRows(rows, dest)
+	observeDuration(sp, start, op, err)
+	return err
+}
+
+// usesDollarPlaceholders reports whether driverName addresses a database that
+// expects $1, $2, ... placeholders rather than ?. driverName is either the
+// name a caller passed to sql.Open/sql.Register, or that name suffixed by
+// WrapDriver (e.g. "postgres-patron"), so this matches on the dialect prefix
+// rather than requiring an exact name.
+func usesDollarPlaceholders(driverName string) bool {
+	return strings.HasPrefix(driverName, "postgres") || strings.HasPrefix(driverName, "pgx")
+}
+
+// bindNamed rewrites `:name` placeholders in query into positional ones,
+// returning the bound arguments in placeholder order. arg must be a struct or
+// a pointer to one; fields are matched by their `db` tag, falling back to the
+// lower-cased field name.
+func bindNamed(driverName, query string, arg interface{}) (string, []interface{}, error) {
+	matches := findNamedParams(query)
+	if len(matches) == 0 {
+		return query, nil, nil
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("named argument must be a struct, got %s", v.Kind())
+	}
+	fieldsByTag := structFieldsByDBTag(v.Type())
+
+	positional := !usesDollarPlaceholders(driverName)
+
+	var b strings.Builder
+	args := make([]interface{}, 0, len(matches))
+	last := 0
+	for i, m := range matches {
+		b.WriteString(query[last:m[0]])
+		name := query[m[0]+1 : m[1]]
+		idx, ok := fieldsByTag[name]
+		if !ok {
+			return "", nil, fmt.Errorf("no field found for named parameter %q", name)
+		}
+		args = append(args, v.Field(idx).Interface())
+		if positional {
+			b.WriteString("?")
+		} else {
+			b.WriteString("$")
+			b.WriteString(strconv.Itoa(i + 1))
+		}
+		last = m[1]
+	}
+	b.WriteString(query[last:])
+
+	return b.String(), args, nil
+}
+
+// findNamedParams returns the [start, end) byte ranges of every `:name` placeholder in query,
+// each range including the leading colon. It skips over single-quoted string literals, so a
+// colon inside a quoted value is never mistaken for a placeholder, and it skips `::`, so a
+// Postgres type cast such as id::text is not parsed as the named parameter :text.
+func findNamedParams(query string) [][2]int {
+	var matches [][2]int
+	inQuote := false
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case inQuote:
+			if c == '\'' {
+				inQuote = false
+			}
+			i++
+		case c == '\'':
+			inQuote = true
+			i++
+		case c != ':':
+			i++
+		case i+1 < len(query) && query[i+1] == ':':
+			// Postgres type cast (e.g. id::text), not a placeholder.
+			i += 2
+		default:
+			j := i + 1
+			if j >= len(query) || !isNameStartByte(query[j]) {
+				i++
+				continue
+			}
+			for j < len(query) && isNameByte(query[j]) {
+				j++
+			}
+			matches = append(matches, [2]int{i, j})
+			i = j
+		}
+	}
+	return matches
+}
+
+func isNameStartByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameByte(c byte) bool {
+	return isNameStartByte(c) || (c >= '0' && c <= '9')
+}
+
+// structFieldsByDBTag maps the column name every exported field of t binds to
+// (its `db` tag, or its lower-cased name) to that field's index. Fields
+// tagged `db:"-"` are skipped.
+func structFieldsByDBTag(t reflect.Type) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := f.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		if tag == "" {
+			tag = strings.ToLower(f.Name)
+		}
+		fields[tag] = i
+	}
+	return fields
+}
+
+// scanRow scans the current row of rows into dest, a pointer to a struct.
+func scanRow(rows *sql.Rows, dest interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return errors.New("Get destination must be a non-nil pointer to a struct")
+	}
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	targets, err := scanTargets(dv.Elem(), cols)
+	if err != nil {
+		return err
+	}
+
+	return rows.Scan(targets...)
+}
+
+// scanRows scans every row of rows into dest, a pointer to a slice of structs.
+func scanRows(rows *sql.Rows, dest interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.Elem().Kind() != reflect.Slice {
+		return errors.New("Select destination must be a pointer to a slice of structs")
+	}
+	sliceVal := dv.Elem()
+	elemType := sliceVal.Type().Elem()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		elemPtr := reflect.New(elemType)
+		targets, err := scanTargets(elemPtr.Elem(), cols)
+		if err != nil {
+			return err
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return err
+		}
+		sliceVal.Set(reflect.Append(sliceVal, elemPtr.Elem()))
+	}
+
+	return rows.Err()
+}
+
+// scanTargets builds the addressable scan destinations for cols against structVal,
+// a struct, discarding any column that has no matching field.
+func scanTargets(structVal reflect.Value, cols []string) ([]interface{}, error) {
+	if structVal.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("scan destination must be a struct, got %s", structVal.Kind())
+	}
+	fieldsByTag := structFieldsByDBTag(structVal.Type())
+
+	targets := make([]interface{}, len(cols))
+	for i, col := range cols {
+		idx, ok := fieldsByTag[col]
+		if !ok {
+			var discard interface{}
+			targets[i] = &discard
+			continue
+		}
+		targets[i] = structVal.Field(idx).Addr().Interface()
+	}
+	return targets, nil
+}
@@ -0,0 +1,142 @@
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+type namedTestArg struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+func TestBindNamed_Positional(t *testing.T) {
+	query, args, err := bindNamed("mysql", "select * from t where id = :id and name = :name", namedTestArg{ID: 1, Name: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "select * from t where id = ? and name = ?"; query != want {
+		t.Fatalf("query = %q, want %q", query, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1, "a"}) {
+		t.Fatalf("args = %v, want [1 a]", args)
+	}
+}
+
+func TestBindNamed_Dollar(t *testing.T) {
+	query, args, err := bindNamed("postgres", "select * from t where id = :id and name = :name", namedTestArg{ID: 1, Name: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "select * from t where id = $1 and name = $2"; query != want {
+		t.Fatalf("query = %q, want %q", query, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1, "a"}) {
+		t.Fatalf("args = %v, want [1 a]", args)
+	}
+}
+
+func TestBindNamed_WrapDriverSuffixUsesDollar(t *testing.T) {
+	query, _, err := bindNamed("postgres-patron", "select :id", namedTestArg{ID: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "select $1"; query != want {
+		t.Fatalf("query = %q, want %q", query, want)
+	}
+}
+
+func TestBindNamed_IgnoresPostgresTypeCast(t *testing.T) {
+	query, args, err := bindNamed("postgres", "select :id::text as id", namedTestArg{ID: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "select $1::text as id"; query != want {
+		t.Fatalf("query = %q, want %q (:: cast must not be parsed as a named parameter)", query, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1}) {
+		t.Fatalf("args = %v, want [1]", args)
+	}
+}
+
+func TestBindNamed_IgnoresColonInsideStringLiteral(t *testing.T) {
+	query, args, err := bindNamed("mysql", "select :id, 'literal:notaparam'", namedTestArg{ID: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "select ?, 'literal:notaparam'"; query != want {
+		t.Fatalf("query = %q, want %q (colon inside a string literal must not be parsed as a named parameter)", query, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1}) {
+		t.Fatalf("args = %v, want [1]", args)
+	}
+}
+
+func TestBindNamed_NoPlaceholders(t *testing.T) {
+	query, args, err := bindNamed("mysql", "select * from t", namedTestArg{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query != "select * from t" {
+		t.Fatalf("query = %q, want unchanged", query)
+	}
+	if args != nil {
+		t.Fatalf("args = %v, want nil", args)
+	}
+}
+
+func TestBindNamed_UnknownField(t *testing.T) {
+	_, _, err := bindNamed("mysql", "select :missing", namedTestArg{})
+	if err == nil {
+		t.Fatal("expected an error for an unmatched named parameter")
+	}
+}
+
+func TestBindNamed_NonStructArg(t *testing.T) {
+	_, _, err := bindNamed("mysql", "select :id", 5)
+	if err == nil {
+		t.Fatal("expected an error when arg is not a struct")
+	}
+}
+
+type scanTestRow struct {
+	ID       int    `db:"id"`
+	Name     string `db:"name"`
+	Ignored  string `db:"-"`
+	Untagged string
+}
+
+func TestScanTargets(t *testing.T) {
+	row := scanTestRow{}
+	rv := reflect.ValueOf(&row).Elem()
+
+	targets, err := scanTargets(rv, []string{"id", "name", "untagged", "unknown_column"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targets) != 4 {
+		t.Fatalf("expected 4 scan targets, got %d", len(targets))
+	}
+
+	*(targets[0].(*int)) = 7
+	*(targets[1].(*string)) = "patron"
+	*(targets[2].(*string)) = "untagged-value"
+
+	if row.ID != 7 || row.Name != "patron" || row.Untagged != "untagged-value" {
+		t.Fatalf("scanTargets did not address the expected fields, got %+v", row)
+	}
+}
+
+func TestScanTargets_SkipsDBDashTag(t *testing.T) {
+	fieldsByTag := structFieldsByDBTag(reflect.TypeOf(scanTestRow{}))
+	if _, ok := fieldsByTag["ignored"]; ok {
+		t.Fatal(`a field tagged db:"-" must not be addressable by column name`)
+	}
+}
+
+func TestScanTargets_NonStructDestination(t *testing.T) {
+	_, err := scanTargets(reflect.ValueOf(5), []string{"id"})
+	if err == nil {
+		t.Fatal("expected an error when the destination is not a struct")
+	}
+}
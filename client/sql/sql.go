@@ -36,7 +36,7 @@ func init() {
 }
 
 type connInfo struct {
-	instance, user string
+	instance, user, driver string
 }
 
 func (c *connInfo) startSpan(ctx context.Context, opName, stmt string, tags ...opentracing.Tag) (opentracing.Span, context.Context) {
@@ -156,7 +156,11 @@ type DB struct {
 	db *sql.DB
 }
 
-// Open opens a database.
+// Open opens a database. The DB/Conn/Tx/Stmt types returned by this package
+// are a thin facade around the stock *sql.DB obtained this way: tracing and
+// metrics are recorded both here and, if driverName was registered through
+// WrapDriver, down at the driver level, so code that reaches for db.DB() and
+// hands it to sqlx, GORM or goose stays instrumented.
 func Open(driverName, dataSourceName string) (*DB, error) {
 	db, err := sql.Open(driverName, dataSourceName)
 	if err != nil {
@@ -164,10 +168,11 @@ func Open(driverName, dataSourceName string) (*DB, error) {
 	}
 	info := parseDSN(dataSourceName)
 
-	return &DB{connInfo: connInfo{info.DBName, info.User}, db: db}, nil
+	return &DB{connInfo: connInfo{instance: info.DBName, user: info.User, driver: driverName}, db: db}, nil
 }
 
-// OpenDB opens a database.
+// OpenDB opens a database. Pass a connector obtained from WrapConnector to
+// also get driver-level tracing on the resulting *sql.DB.
 func OpenDB(c driver.Connector) *DB {
 	db := sql.OpenDB(c)
 	return &DB{db: db}
@@ -195,7 +200,7 @@ func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Tx{tx: tx, connInfo: connInfo{instance: db.instance, user: db.user}}, nil
+	return &Tx{tx: tx, connInfo: connInfo{instance: db.instance, user: db.user, driver: db.driver}}, nil
 }
 
 // Close closes the database, releasing any open resources.
@@ -0,0 +1,156 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	patronerrors "github.com/beatlabs/patron/errors"
+	"github.com/beatlabs/patron/log"
+	"github.com/beatlabs/patron/trace"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+const (
+	// sqsBatchMaxEntries is the maximum number of entries SendMessageBatch accepts per call.
+	sqsBatchMaxEntries = 10
+	// sqsBatchMaxPayloadBytes is the maximum aggregate payload SendMessageBatch accepts per call.
+	sqsBatchMaxPayloadBytes = 256 * 1024
+)
+
+// PublishBatch tries to publish a batch of messages to SQS, storing tracing information per
+// entry. Inputs larger than 10 entries or 256 KiB aggregate payload are automatically split into
+// multiple SendMessageBatchWithContext calls, with the Successful/Failed slices of every call
+// merged into a single output for the caller. Every entry in input.Entries is accounted for in
+// the returned output even if a chunk's call fails: a failing chunk's entries are recorded as
+// Failed rather than dropped, so that a single bad chunk can't make later chunks go unattempted
+// and silently vanish from both Successful and Failed.
+func (p Publisher) PublishBatch(ctx context.Context, input *sqs.SendMessageBatchInput) (*sqs.SendMessageBatchOutput, error) {
+	span, ctx := trace.ChildSpan(ctx, trace.ComponentOpName(publisherComponent, *input.QueueUrl), publisherComponent,
+		ext.SpanKindProducer, opentracing.Tag{Key: "sqs.batch.size", Value: len(input.Entries)})
+
+	out := &sqs.SendMessageBatchOutput{}
+
+	var errs []error
+	for _, chunk := range chunkEntries(input.Entries) {
+		chunkOut, err := p.publishChunk(ctx, input.QueueUrl, chunk)
+		if err != nil {
+			errs = append(errs, err)
+			out.Failed = append(out.Failed, failedEntries(chunk, err)...)
+			continue
+		}
+		out.Successful = append(out.Successful, chunkOut.Successful...)
+		out.Failed = append(out.Failed, chunkOut.Failed...)
+	}
+
+	err := patronerrors.Aggregate(errs...)
+	trace.SpanComplete(span, err)
+	if err != nil {
+		return out, fmt.Errorf("failed to publish message batch: %w", err)
+	}
+
+	return out, nil
+}
+
+// failedEntries synthesizes a Failed result entry for every entry in chunk, for use when the
+// SendMessageBatchWithContext call for that chunk failed outright and produced no per-entry
+// results of its own.
+func failedEntries(chunk []*sqs.SendMessageBatchRequestEntry, err error) []*sqs.BatchResultErrorEntry {
+	failed := make([]*sqs.BatchResultErrorEntry, 0, len(chunk))
+	for _, entry := range chunk {
+		failed = append(failed, &sqs.BatchResultErrorEntry{
+			Id:          entry.Id,
+			Message:     aws.String(err.Error()),
+			Code:        aws.String("InternalError"),
+			SenderFault: aws.Bool(false),
+		})
+	}
+	return failed
+}
+
+// publishChunk sends a single SendMessageBatch request, tracing and observing each of its entries.
+func (p Publisher) publishChunk(ctx context.Context, queueURL *string, entries []*sqs.SendMessageBatchRequestEntry) (*sqs.SendMessageBatchOutput, error) {
+	type entrySpan struct {
+		span  opentracing.Span
+		start time.Time
+	}
+	spans := make(map[string]entrySpan, len(entries))
+
+	for _, entry := range entries {
+		sp, _ := trace.ChildSpan(ctx, trace.ComponentOpName(publisherComponent, *queueURL), publisherComponent, ext.SpanKindProducer)
+		if err := injectHeaders(sp, &entry.MessageAttributes); err != nil {
+			log.FromContext(ctx).Errorf("failed to inject trace headers: %v", err)
+		}
+		spans[*entry.Id] = entrySpan{span: sp, start: time.Now()}
+	}
+
+	out, err := p.api.SendMessageBatchWithContext(ctx, &sqs.SendMessageBatchInput{QueueUrl: queueURL, Entries: entries})
+	if err != nil {
+		for _, es := range spans {
+			observePublish(es.span, es.start, *queueURL, err)
+		}
+		return nil, err
+	}
+
+	failed := make(map[string]*sqs.BatchResultErrorEntry, len(out.Failed))
+	for _, f := range out.Failed {
+		failed[*f.Id] = f
+	}
+
+	for id, es := range spans {
+		var entryErr error
+		if f, ok := failed[id]; ok {
+			entryErr = fmt.Errorf("entry %s failed: %s", id, *f.Message)
+		}
+		observePublish(es.span, es.start, *queueURL, entryErr)
+	}
+
+	return out, nil
+}
+
+// chunkEntries splits entries into batches of at most 10 entries and 256 KiB of aggregate
+// payload, SendMessageBatch's limits. An entry that alone exceeds the payload limit is placed in
+// a chunk of its own. Sizes are measured as the entries are passed in, so they do not include
+// the tracing attributes that publishChunk adds afterwards.
+func chunkEntries(entries []*sqs.SendMessageBatchRequestEntry) [][]*sqs.SendMessageBatchRequestEntry {
+	var chunks [][]*sqs.SendMessageBatchRequestEntry
+	var current []*sqs.SendMessageBatchRequestEntry
+	var size int
+
+	for _, entry := range entries {
+		entrySize := entryPayloadSize(entry)
+		if len(current) > 0 && (len(current) == sqsBatchMaxEntries || size+entrySize > sqsBatchMaxPayloadBytes) {
+			chunks = append(chunks, current)
+			current = nil
+			size = 0
+		}
+		current = append(current, entry)
+		size += entrySize
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks
+}
+
+// entryPayloadSize approximates the wire size of a single batch entry.
+func entryPayloadSize(entry *sqs.SendMessageBatchRequestEntry) int {
+	size := 0
+	if entry.MessageBody != nil {
+		size += len(*entry.MessageBody)
+	}
+	for name, attr := range entry.MessageAttributes {
+		size += len(name)
+		if attr.DataType != nil {
+			size += len(*attr.DataType)
+		}
+		if attr.StringValue != nil {
+			size += len(*attr.StringValue)
+		}
+	}
+	return size
+}
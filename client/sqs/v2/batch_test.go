@@ -0,0 +1,145 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+)
+
+func entryWithBody(id string, bodySize int) *sqs.SendMessageBatchRequestEntry {
+	return &sqs.SendMessageBatchRequestEntry{
+		Id:          aws.String(id),
+		MessageBody: aws.String(string(make([]byte, bodySize))),
+	}
+}
+
+func TestChunkEntries_UnderLimits(t *testing.T) {
+	entries := make([]*sqs.SendMessageBatchRequestEntry, 5)
+	for i := range entries {
+		entries[i] = entryWithBody(strconv.Itoa(i), 10)
+	}
+
+	chunks := chunkEntries(entries)
+	if len(chunks) != 1 {
+		t.Fatalf("expected 1 chunk, got %d", len(chunks))
+	}
+	if len(chunks[0]) != 5 {
+		t.Fatalf("expected 5 entries in the chunk, got %d", len(chunks[0]))
+	}
+}
+
+func TestChunkEntries_EntryCountBoundary(t *testing.T) {
+	entries := make([]*sqs.SendMessageBatchRequestEntry, sqsBatchMaxEntries)
+	for i := range entries {
+		entries[i] = entryWithBody(strconv.Itoa(i), 10)
+	}
+
+	chunks := chunkEntries(entries)
+	if len(chunks) != 1 {
+		t.Fatalf("exactly %d entries must fit in a single chunk, got %d chunks", sqsBatchMaxEntries, len(chunks))
+	}
+
+	entries = append(entries, entryWithBody("extra", 10))
+	chunks = chunkEntries(entries)
+	if len(chunks) != 2 {
+		t.Fatalf("%d entries must split into 2 chunks, got %d", sqsBatchMaxEntries+1, len(chunks))
+	}
+	if len(chunks[0]) != sqsBatchMaxEntries || len(chunks[1]) != 1 {
+		t.Fatalf("expected chunk sizes [%d, 1], got [%d, %d]", sqsBatchMaxEntries, len(chunks[0]), len(chunks[1]))
+	}
+}
+
+func TestChunkEntries_PayloadSizeBoundary(t *testing.T) {
+	entries := []*sqs.SendMessageBatchRequestEntry{
+		entryWithBody("a", sqsBatchMaxPayloadBytes/2),
+		entryWithBody("b", sqsBatchMaxPayloadBytes/2),
+	}
+
+	chunks := chunkEntries(entries)
+	if len(chunks) != 1 {
+		t.Fatalf("entries totalling exactly the payload limit must fit in one chunk, got %d chunks", len(chunks))
+	}
+
+	entries = append(entries, entryWithBody("c", 1))
+	chunks = chunkEntries(entries)
+	if len(chunks) != 2 {
+		t.Fatalf("entries exceeding the payload limit must split into 2 chunks, got %d", len(chunks))
+	}
+	if len(chunks[0]) != 2 || len(chunks[1]) != 1 {
+		t.Fatalf("expected chunk sizes [2, 1], got [%d, %d]", len(chunks[0]), len(chunks[1]))
+	}
+}
+
+func TestChunkEntries_SingleEntryOverPayloadLimit(t *testing.T) {
+	entries := []*sqs.SendMessageBatchRequestEntry{
+		entryWithBody("huge", sqsBatchMaxPayloadBytes+1),
+	}
+
+	chunks := chunkEntries(entries)
+	if len(chunks) != 1 || len(chunks[0]) != 1 {
+		t.Fatalf("a single oversized entry must still be placed in its own chunk, got %v", chunks)
+	}
+}
+
+// fakeSQSAPI embeds sqsiface.SQSAPI so it satisfies the interface without implementing every
+// method; only sendMessageBatch is ever invoked by the tests below.
+type fakeSQSAPI struct {
+	sqsiface.SQSAPI
+	sendMessageBatch func(*sqs.SendMessageBatchInput) (*sqs.SendMessageBatchOutput, error)
+}
+
+func (f *fakeSQSAPI) SendMessageBatchWithContext(_ aws.Context, input *sqs.SendMessageBatchInput, _ ...request.Option) (*sqs.SendMessageBatchOutput, error) {
+	return f.sendMessageBatch(input)
+}
+
+func TestPublishBatch_FailedChunkDoesNotDropLaterChunks(t *testing.T) {
+	entries := make([]*sqs.SendMessageBatchRequestEntry, sqsBatchMaxEntries+1)
+	for i := range entries {
+		entries[i] = entryWithBody(strconv.Itoa(i), 10)
+	}
+
+	callCount := 0
+	api := &fakeSQSAPI{
+		sendMessageBatch: func(input *sqs.SendMessageBatchInput) (*sqs.SendMessageBatchOutput, error) {
+			callCount++
+			if callCount == 1 {
+				return nil, awserr.New("InternalError", "boom", errors.New("boom"))
+			}
+			successful := make([]*sqs.SendMessageBatchResultEntry, 0, len(input.Entries))
+			for _, e := range input.Entries {
+				successful = append(successful, &sqs.SendMessageBatchResultEntry{Id: e.Id})
+			}
+			return &sqs.SendMessageBatchOutput{Successful: successful}, nil
+		},
+	}
+
+	p, err := New(api)
+	if err != nil {
+		t.Fatalf("failed to create publisher: %v", err)
+	}
+
+	out, err := p.PublishBatch(context.Background(), &sqs.SendMessageBatchInput{
+		QueueUrl: aws.String("queue"),
+		Entries:  entries,
+	})
+	if err == nil {
+		t.Fatal("expected an error for the failed chunk")
+	}
+	if callCount != 2 {
+		t.Fatalf("expected both chunks to be attempted, got %d calls", callCount)
+	}
+	if len(out.Successful)+len(out.Failed) != len(entries) {
+		t.Fatalf("expected every input entry to appear in Successful or Failed, got %d successful + %d failed for %d entries",
+			len(out.Successful), len(out.Failed), len(entries))
+	}
+	if len(out.Failed) != sqsBatchMaxEntries {
+		t.Fatalf("expected the first (failing) chunk's %d entries to be reported as Failed, got %d", sqsBatchMaxEntries, len(out.Failed))
+	}
+}
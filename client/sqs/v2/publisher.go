@@ -57,7 +57,7 @@ func New(api sqsiface.SQSAPI) (Publisher, error) {
 func (p Publisher) Publish(ctx context.Context, msg *sqs.SendMessageInput) (messageID string, err error) {
 	span, _ := trace.ChildSpan(ctx, trace.ComponentOpName(publisherComponent, *msg.QueueUrl), publisherComponent, ext.SpanKindProducer)
 
-	if err := injectHeaders(span, msg); err != nil {
+	if err := injectHeaders(span, &msg.MessageAttributes); err != nil {
 		log.FromContext(ctx).Errorf("failed to inject trace headers: %v", err)
 	}
 
@@ -82,18 +82,18 @@ func (c sqsHeadersCarrier) Set(key, val string) {
 	c[key] = val
 }
 
-// injectHeaders injects the SQS headers carrier's headers into the message's attributes.
-func injectHeaders(span opentracing.Span, input *sqs.SendMessageInput) error {
+// injectHeaders injects the SQS headers carrier's headers into the given message attributes.
+func injectHeaders(span opentracing.Span, attrs *map[string]*sqs.MessageAttributeValue) error {
 	carrier := sqsHeadersCarrier{}
 	if err := span.Tracer().Inject(span.Context(), opentracing.TextMap, &carrier); err != nil {
 		return fmt.Errorf("failed to inject tracing headers: %w", err)
 	}
-	if input.MessageAttributes == nil {
-		input.MessageAttributes = make(map[string]*sqs.MessageAttributeValue)
+	if *attrs == nil {
+		*attrs = make(map[string]*sqs.MessageAttributeValue)
 	}
 
 	for k, v := range carrier {
-		input.MessageAttributes[k] = &sqs.MessageAttributeValue{
+		(*attrs)[k] = &sqs.MessageAttributeValue{
 			DataType:    aws.String(attributeDataTypeString),
 			StringValue: aws.String(v.(string)),
 		}